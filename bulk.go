@@ -0,0 +1,322 @@
+package postcodesio
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+)
+
+// bulkBatchSize is the largest batch postcodes.io accepts in a single
+// call to either of its bulk endpoints.
+const bulkBatchSize = 100
+
+// defaultBulkWorkers is how many batches BulkGeocode and
+// BulkReverseGeocode send to postcodes.io at once when WithWorkers is
+// not given.
+const defaultBulkWorkers = 4
+
+// BulkOption configures a call to BulkGeocode or BulkReverseGeocode.
+type BulkOption func(*bulkParams)
+
+type bulkParams struct {
+	workers int
+}
+
+// WithWorkers sets how many batches of up to 100 items are sent to
+// postcodes.io concurrently. The default is 4.
+func WithWorkers(n int) BulkOption {
+	return func(p *bulkParams) {
+		p.workers = n
+	}
+}
+
+// batchRanges splits a slice of length n into [start, end) ranges of at
+// most size items each.
+func batchRanges(n, size int) [][2]int {
+	var ranges [][2]int
+	for start := 0; start < n; start += size {
+		end := start + size
+		if end > n {
+			end = n
+		}
+		ranges = append(ranges, [2]int{start, end})
+	}
+	return ranges
+}
+
+// runBatches runs do(i) for every i in [0, n) using a pool of workers
+// goroutines, blocking until all of them have finished.
+func runBatches(n, workers int, do func(i int)) {
+
+	if workers <= 0 {
+		workers = defaultBulkWorkers
+	}
+	if workers > n {
+		workers = n
+	}
+
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				do(i)
+			}
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		jobs <- i
+	}
+	close(jobs)
+
+	wg.Wait()
+}
+
+func decorateBulkError(err error) error {
+	return errors.New("postcodes.io: could not complete bulk lookup: " + err.Error())
+}
+
+type bulkPostcodeItem struct {
+	Query  string          `json:"query"`
+	Result *PostcodeResult `json:"result"`
+}
+
+func (c *Client) postBulkPostcodes(ctx context.Context, postcodes []string) ([]bulkPostcodeItem, error) {
+
+	body, err := json.Marshal(struct {
+		Postcodes []string `json:"postcodes"`
+	}{Postcodes: postcodes})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.newRequest(ctx, http.MethodPost, c.baseURL+"/postcodes", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Body.Close()
+
+	if r.StatusCode != 200 {
+		return nil, errorFromHTTPCode(r.StatusCode)
+	}
+
+	var items []bulkPostcodeItem
+	if err := decodeResultPayload(r, &items); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// BulkGeocode looks up the full postcodes.io record for each of the
+// given UK postcodes, using DefaultClient, batching the requests in
+// groups of up to 100 and issuing them through a worker pool (see
+// WithWorkers). Results are returned in the same order as postcodes.
+//
+// A postcode that postcodes.io could not find does not fail the whole
+// batch: its entry in errs holds NotFound and its entry in results is
+// the zero PostcodeResult. err is only set when a batch request itself
+// failed, e.g. a network error.
+func BulkGeocode(postcodes []string, opts ...BulkOption) ([]PostcodeResult, []error, error) {
+	return DefaultClient.BulkGeocode(postcodes, opts...)
+}
+
+// BulkGeocodeContext is like BulkGeocode but carries ctx onto every
+// underlying HTTP request, so callers can cancel or time out the whole
+// batch.
+func BulkGeocodeContext(ctx context.Context, postcodes []string, opts ...BulkOption) ([]PostcodeResult, []error, error) {
+	return DefaultClient.BulkGeocodeContext(ctx, postcodes, opts...)
+}
+
+// BulkGeocode looks up the full postcodes.io record for each of the
+// given UK postcodes, batching the requests in groups of up to 100 and
+// issuing them through a worker pool (see WithWorkers). Results are
+// returned in the same order as postcodes.
+func (c *Client) BulkGeocode(postcodes []string, opts ...BulkOption) ([]PostcodeResult, []error, error) {
+	return c.BulkGeocodeContext(context.Background(), postcodes, opts...)
+}
+
+// BulkGeocodeContext is like BulkGeocode but carries ctx onto every
+// underlying HTTP request, so callers can cancel or time out the whole
+// batch.
+func (c *Client) BulkGeocodeContext(ctx context.Context, postcodes []string, opts ...BulkOption) (results []PostcodeResult, errs []error, err error) {
+
+	var p bulkParams
+	for _, opt := range opts {
+		opt(&p)
+	}
+
+	ranges := batchRanges(len(postcodes), bulkBatchSize)
+	results = make([]PostcodeResult, len(postcodes))
+	errs = make([]error, len(postcodes))
+
+	var mu sync.Mutex
+	var firstErr error
+
+	runBatches(len(ranges), p.workers, func(i int) {
+		start, end := ranges[i][0], ranges[i][1]
+
+		items, batchErr := c.postBulkPostcodes(ctx, postcodes[start:end])
+		if batchErr != nil {
+			for j := start; j < end; j++ {
+				errs[j] = batchErr
+			}
+			mu.Lock()
+			if firstErr == nil {
+				firstErr = decorateBulkError(batchErr)
+			}
+			mu.Unlock()
+			return
+		}
+
+		for j, item := range items {
+			if item.Result == nil {
+				errs[start+j] = NotFound
+				continue
+			}
+			results[start+j] = *item.Result
+		}
+	})
+
+	return results, errs, firstErr
+}
+
+type bulkGeolocationQuery struct {
+	Longitude  float64 `json:"longitude"`
+	Latitude   float64 `json:"latitude"`
+	Radius     int     `json:"radius,omitempty"`
+	Limit      int     `json:"limit,omitempty"`
+	Widesearch bool    `json:"widesearch,omitempty"`
+}
+
+type bulkGeolocationItem struct {
+	Query  bulkGeolocationQuery `json:"query"`
+	Result []PostcodeResult     `json:"result"`
+}
+
+func (c *Client) postBulkGeolocations(ctx context.Context, queries []bulkGeolocationQuery) ([]bulkGeolocationItem, error) {
+
+	body, err := json.Marshal(struct {
+		Geolocations []bulkGeolocationQuery `json:"geolocations"`
+	}{Geolocations: queries})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.newRequest(ctx, http.MethodPost, c.baseURL+"/postcodes", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Body.Close()
+
+	if r.StatusCode != 200 {
+		return nil, errorFromHTTPCode(r.StatusCode)
+	}
+
+	var items []bulkGeolocationItem
+	if err := decodeResultPayload(r, &items); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// BulkReverseGeocode finds the postcodes nearest to each of the given
+// points, using DefaultClient and postcodes.io's default radius and
+// limit, batching the requests in groups of up to 100 and issuing them
+// through a worker pool (see WithWorkers). Results are returned in the
+// same order as points.
+//
+// A point for which postcodes.io found nothing does not fail the whole
+// batch: its entry in errs holds NoResults and its entry in results is
+// nil. err is only set when a batch request itself failed, e.g. a
+// network error.
+func BulkReverseGeocode(points []GeoPoint, opts ...BulkOption) ([][]PostcodeResult, []error, error) {
+	return DefaultClient.BulkReverseGeocode(points, opts...)
+}
+
+// BulkReverseGeocodeContext is like BulkReverseGeocode but carries ctx
+// onto every underlying HTTP request, so callers can cancel or time out
+// the whole batch.
+func BulkReverseGeocodeContext(ctx context.Context, points []GeoPoint, opts ...BulkOption) ([][]PostcodeResult, []error, error) {
+	return DefaultClient.BulkReverseGeocodeContext(ctx, points, opts...)
+}
+
+// BulkReverseGeocode finds the postcodes nearest to each of the given
+// points, using postcodes.io's default radius and limit, batching the
+// requests in groups of up to 100 and issuing them through a worker
+// pool (see WithWorkers). Results are returned in the same order as
+// points.
+func (c *Client) BulkReverseGeocode(points []GeoPoint, opts ...BulkOption) ([][]PostcodeResult, []error, error) {
+	return c.BulkReverseGeocodeContext(context.Background(), points, opts...)
+}
+
+// BulkReverseGeocodeContext is like BulkReverseGeocode but carries ctx
+// onto every underlying HTTP request, so callers can cancel or time out
+// the whole batch.
+func (c *Client) BulkReverseGeocodeContext(ctx context.Context, points []GeoPoint, opts ...BulkOption) (results [][]PostcodeResult, errs []error, err error) {
+
+	var p bulkParams
+	for _, opt := range opts {
+		opt(&p)
+	}
+
+	queries := make([]bulkGeolocationQuery, len(points))
+	for i, pt := range points {
+		queries[i] = bulkGeolocationQuery{
+			Longitude: pt.Longitude,
+			Latitude:  pt.Latitude,
+		}
+	}
+
+	ranges := batchRanges(len(queries), bulkBatchSize)
+	results = make([][]PostcodeResult, len(points))
+	errs = make([]error, len(points))
+
+	var mu sync.Mutex
+	var firstErr error
+
+	runBatches(len(ranges), p.workers, func(i int) {
+		start, end := ranges[i][0], ranges[i][1]
+
+		items, batchErr := c.postBulkGeolocations(ctx, queries[start:end])
+		if batchErr != nil {
+			for j := start; j < end; j++ {
+				errs[j] = batchErr
+			}
+			mu.Lock()
+			if firstErr == nil {
+				firstErr = decorateBulkError(batchErr)
+			}
+			mu.Unlock()
+			return
+		}
+
+		for j, item := range items {
+			if len(item.Result) == 0 {
+				errs[start+j] = NoResults
+				continue
+			}
+			results[start+j] = item.Result
+		}
+	})
+
+	return results, errs, firstErr
+}
@@ -0,0 +1,102 @@
+package postcodesio
+
+import (
+	"container/list"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Cache lets a Client avoid a round-trip to postcodes.io for postcodes
+// it has already looked up. Keys are postcodes normalized with
+// NormalizePostcode. Get reports whether key is present and not
+// expired; Set stores r against key, valid for ttl.
+type Cache interface {
+	Get(key string) (PostcodeResult, bool)
+	Set(key string, r PostcodeResult, ttl time.Duration)
+}
+
+// NormalizePostcode upper-cases pc, trims it and collapses each run of
+// whitespace to a single space, so that e.g. "sw1a 1aa", " SW1A 1AA"
+// and "SW1A  1AA" all produce the same cache key. No space is inserted,
+// so "SW1A1AA" produces a different key from "SW1A 1AA".
+func NormalizePostcode(pc string) string {
+	return strings.Join(strings.Fields(strings.ToUpper(pc)), " ")
+}
+
+type lruEntry struct {
+	key       string
+	result    PostcodeResult
+	expiresAt time.Time
+}
+
+// LRUCache is a fixed-size, TTL-aware in-memory Cache implementation.
+// A zero LRUCache is not ready to use; construct one with NewLRUCache.
+type LRUCache struct {
+	mu    sync.Mutex
+	size  int
+	ll    *list.List
+	items map[string]*list.Element
+}
+
+// NewLRUCache creates an LRUCache holding at most size entries,
+// evicting the least recently used entry once that limit is reached. A
+// size of 0 or less means the cache never evicts on size alone.
+func NewLRUCache(size int) *LRUCache {
+	return &LRUCache{
+		size:  size,
+		ll:    list.New(),
+		items: make(map[string]*list.Element),
+	}
+}
+
+// Get implements Cache.
+func (c *LRUCache) Get(key string) (PostcodeResult, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		return PostcodeResult{}, false
+	}
+
+	entry := el.Value.(*lruEntry)
+	if !entry.expiresAt.IsZero() && time.Now().After(entry.expiresAt) {
+		c.ll.Remove(el)
+		delete(c.items, key)
+		return PostcodeResult{}, false
+	}
+
+	c.ll.MoveToFront(el)
+	return entry.result, true
+}
+
+// Set implements Cache. A ttl of 0 means the entry never expires.
+func (c *LRUCache) Set(key string, r PostcodeResult, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var expiresAt time.Time
+	if ttl != 0 {
+		expiresAt = time.Now().Add(ttl)
+	}
+
+	if el, ok := c.items[key]; ok {
+		c.ll.MoveToFront(el)
+		entry := el.Value.(*lruEntry)
+		entry.result = r
+		entry.expiresAt = expiresAt
+		return
+	}
+
+	el := c.ll.PushFront(&lruEntry{key: key, result: r, expiresAt: expiresAt})
+	c.items[key] = el
+
+	if c.size > 0 && c.ll.Len() > c.size {
+		oldest := c.ll.Back()
+		if oldest != nil {
+			c.ll.Remove(oldest)
+			delete(c.items, oldest.Value.(*lruEntry).key)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package postcodesio
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+)
+
+// defaultCacheTTL is how long a Client keeps a cached PostcodeResult
+// before treating it as stale, when WithCache is used without
+// WithCacheTTL. Postcode data rarely changes, so this is deliberately
+// generous.
+const defaultCacheTTL = 24 * time.Hour
+
+// Client is a postcodes.io API client. Use NewClient to construct one;
+// the zero Client is not ready to use.
+type Client struct {
+	httpClient *http.Client
+	baseURL    string
+	userAgent  string
+	cache      Cache
+	cacheTTL   time.Duration
+}
+
+// Option configures a Client constructed by NewClient.
+type Option func(*Client)
+
+// WithHTTPClient sets the http.Client used to make requests, letting
+// callers configure transport-level behaviour such as proxies or TLS
+// settings. The default is a new http.Client with no timeout.
+func WithHTTPClient(h *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = h
+	}
+}
+
+// WithBaseURL points the client at a different postcodes.io instance,
+// e.g. a self-hosted deployment. The default is https://api.postcodes.io.
+func WithBaseURL(url string) Option {
+	return func(c *Client) {
+		c.baseURL = url
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(ua string) Option {
+	return func(c *Client) {
+		c.userAgent = ua
+	}
+}
+
+// WithTimeout sets how long a request is allowed to take before it is
+// cancelled. It sets the Timeout field on the client's http.Client, so
+// it should be given before WithHTTPClient if both are used, otherwise
+// it will override the timeout of the supplied http.Client.
+func WithTimeout(d time.Duration) Option {
+	return func(c *Client) {
+		c.httpClient.Timeout = d
+	}
+}
+
+// WithCache enables caching of LookupPostcode/Geocode results on a
+// Client, avoiding a round-trip to postcodes.io for postcodes that have
+// already been looked up. Geocode of an outward code is not cached. See
+// WithCacheTTL to change how long entries are kept.
+func WithCache(cache Cache) Option {
+	return func(c *Client) {
+		c.cache = cache
+	}
+}
+
+// WithCacheTTL sets how long results stay in the cache configured by
+// WithCache before the Client treats them as stale. It has no effect
+// unless WithCache is also used. The default is 24 hours.
+func WithCacheTTL(ttl time.Duration) Option {
+	return func(c *Client) {
+		c.cacheTTL = ttl
+	}
+}
+
+// NewClient creates a Client ready to make requests against
+// postcodes.io, or a self-hosted instance configured with WithBaseURL.
+func NewClient(opts ...Option) *Client {
+	c := &Client{
+		httpClient: &http.Client{},
+		baseURL:    baseURL,
+		cacheTTL:   defaultCacheTTL,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// DefaultClient is the Client used by this package's package-level
+// functions, such as Geocode and ReverseGeocode. Replace it to change
+// their behaviour globally, e.g. to point every call at a self-hosted
+// instance.
+var DefaultClient = NewClient()
+
+func (c *Client) newRequest(ctx context.Context, method, url string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, err
+	}
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return req, nil
+}
+
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	return c.httpClient.Do(req)
+}
@@ -0,0 +1,239 @@
+package postcodesio
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+func decorateError(action string, err error) error {
+	return errors.New("postcodes.io: could not " + action + ": " + err.Error())
+}
+
+// Validate reports whether pc is a real, currently assigned UK
+// postcode, using DefaultClient.
+func Validate(pc string) (bool, error) {
+	return DefaultClient.Validate(pc)
+}
+
+// ValidateContext is like Validate but carries ctx onto the underlying
+// HTTP request, so callers can cancel or time it out.
+func ValidateContext(ctx context.Context, pc string) (bool, error) {
+	return DefaultClient.ValidateContext(ctx, pc)
+}
+
+// Validate reports whether pc is a real, currently assigned UK
+// postcode.
+func (c *Client) Validate(pc string) (bool, error) {
+	return c.ValidateContext(context.Background(), pc)
+}
+
+// ValidateContext is like Validate but carries ctx onto the underlying
+// HTTP request, so callers can cancel or time it out.
+func (c *Client) ValidateContext(ctx context.Context, pc string) (valid bool, err error) {
+
+	uri, err := url.ParseRequestURI(c.baseURL + "/postcodes/" + pc + "/validate")
+	if err != nil {
+		return false, decorateError("validate postcode", err)
+	}
+
+	req, err := c.newRequest(ctx, http.MethodGet, uri.String(), nil)
+	if err != nil {
+		return false, decorateError("validate postcode", err)
+	}
+
+	r, err := c.do(req)
+	if err != nil {
+		return false, decorateError("validate postcode", err)
+	}
+	defer r.Body.Close()
+
+	if r.StatusCode != 200 {
+		return false, decorateError("validate postcode", errorFromHTTPCode(r.StatusCode))
+	}
+
+	err = decodeResultPayload(r, &valid)
+	if err != nil {
+		err = decorateError("validate postcode", err)
+	}
+
+	return valid, err
+}
+
+// Autocomplete returns up to limit full postcodes that start with
+// partial, using DefaultClient. A limit of 0 uses postcodes.io's
+// default of 10.
+func Autocomplete(partial string, limit int) ([]string, error) {
+	return DefaultClient.Autocomplete(partial, limit)
+}
+
+// AutocompleteContext is like Autocomplete but carries ctx onto the
+// underlying HTTP request, so callers can cancel or time it out.
+func AutocompleteContext(ctx context.Context, partial string, limit int) ([]string, error) {
+	return DefaultClient.AutocompleteContext(ctx, partial, limit)
+}
+
+// Autocomplete returns up to limit full postcodes that start with
+// partial. A limit of 0 uses postcodes.io's default of 10.
+func (c *Client) Autocomplete(partial string, limit int) ([]string, error) {
+	return c.AutocompleteContext(context.Background(), partial, limit)
+}
+
+// AutocompleteContext is like Autocomplete but carries ctx onto the
+// underlying HTTP request, so callers can cancel or time it out.
+func (c *Client) AutocompleteContext(ctx context.Context, partial string, limit int) (matches []string, err error) {
+
+	uri, err := url.ParseRequestURI(c.baseURL + "/postcodes/" + partial + "/autocomplete")
+	if err != nil {
+		return nil, decorateError("autocomplete postcode", err)
+	}
+
+	if limit != 0 {
+		q := url.Values{}
+		q.Set("limit", strconv.Itoa(limit))
+		uri.RawQuery = q.Encode()
+	}
+
+	req, err := c.newRequest(ctx, http.MethodGet, uri.String(), nil)
+	if err != nil {
+		return nil, decorateError("autocomplete postcode", err)
+	}
+
+	r, err := c.do(req)
+	if err != nil {
+		return nil, decorateError("autocomplete postcode", err)
+	}
+	defer r.Body.Close()
+
+	if r.StatusCode != 200 {
+		return nil, decorateError("autocomplete postcode", errorFromHTTPCode(r.StatusCode))
+	}
+
+	err = decodeResultPayload(r, &matches)
+	if err != nil {
+		err = decorateError("autocomplete postcode", err)
+	}
+
+	return matches, err
+}
+
+// Nearest returns the postcodes nearest to pc, nearest first, using
+// DefaultClient. A radius or limit of 0 uses postcodes.io's defaults of
+// 100m and 10 results respectively.
+func Nearest(pc string, radius, limit int) ([]PostcodeResult, error) {
+	return DefaultClient.Nearest(pc, radius, limit)
+}
+
+// NearestContext is like Nearest but carries ctx onto the underlying
+// HTTP request, so callers can cancel or time it out.
+func NearestContext(ctx context.Context, pc string, radius, limit int) ([]PostcodeResult, error) {
+	return DefaultClient.NearestContext(ctx, pc, radius, limit)
+}
+
+// Nearest returns the postcodes nearest to pc, nearest first. A radius
+// or limit of 0 uses postcodes.io's defaults of 100m and 10 results
+// respectively.
+func (c *Client) Nearest(pc string, radius, limit int) ([]PostcodeResult, error) {
+	return c.NearestContext(context.Background(), pc, radius, limit)
+}
+
+// NearestContext is like Nearest but carries ctx onto the underlying
+// HTTP request, so callers can cancel or time it out.
+func (c *Client) NearestContext(ctx context.Context, pc string, radius, limit int) (results []PostcodeResult, err error) {
+
+	uri, err := url.ParseRequestURI(c.baseURL + "/postcodes/" + pc + "/nearest")
+	if err != nil {
+		return nil, decorateError("find nearest postcodes", err)
+	}
+
+	q := url.Values{}
+	if radius != 0 {
+		q.Set("radius", strconv.Itoa(radius))
+	}
+	if limit != 0 {
+		q.Set("limit", strconv.Itoa(limit))
+	}
+	uri.RawQuery = q.Encode()
+
+	req, err := c.newRequest(ctx, http.MethodGet, uri.String(), nil)
+	if err != nil {
+		return nil, decorateError("find nearest postcodes", err)
+	}
+
+	r, err := c.do(req)
+	if err != nil {
+		return nil, decorateError("find nearest postcodes", err)
+	}
+	defer r.Body.Close()
+
+	if r.StatusCode != 200 {
+		return nil, decorateError("find nearest postcodes", errorFromHTTPCode(r.StatusCode))
+	}
+
+	err = decodeResultPayload(r, &results)
+	if err != nil {
+		err = decorateError("find nearest postcodes", err)
+	}
+
+	return results, err
+}
+
+// RandomPostcode returns the full postcodes.io record for a randomly
+// selected postcode, using DefaultClient. If outcode is non-empty, the
+// random postcode is restricted to that outward code.
+func RandomPostcode(outcode string) (PostcodeResult, error) {
+	return DefaultClient.RandomPostcode(outcode)
+}
+
+// RandomPostcodeContext is like RandomPostcode but carries ctx onto the
+// underlying HTTP request, so callers can cancel or time it out.
+func RandomPostcodeContext(ctx context.Context, outcode string) (PostcodeResult, error) {
+	return DefaultClient.RandomPostcodeContext(ctx, outcode)
+}
+
+// RandomPostcode returns the full postcodes.io record for a randomly
+// selected postcode. If outcode is non-empty, the random postcode is
+// restricted to that outward code.
+func (c *Client) RandomPostcode(outcode string) (PostcodeResult, error) {
+	return c.RandomPostcodeContext(context.Background(), outcode)
+}
+
+// RandomPostcodeContext is like RandomPostcode but carries ctx onto the
+// underlying HTTP request, so callers can cancel or time it out.
+func (c *Client) RandomPostcodeContext(ctx context.Context, outcode string) (result PostcodeResult, err error) {
+
+	uri, err := url.ParseRequestURI(c.baseURL + "/random/postcodes")
+	if err != nil {
+		return result, decorateError("get random postcode", err)
+	}
+
+	if outcode != "" {
+		q := url.Values{}
+		q.Set("outcode", outcode)
+		uri.RawQuery = q.Encode()
+	}
+
+	req, err := c.newRequest(ctx, http.MethodGet, uri.String(), nil)
+	if err != nil {
+		return result, decorateError("get random postcode", err)
+	}
+
+	r, err := c.do(req)
+	if err != nil {
+		return result, decorateError("get random postcode", err)
+	}
+	defer r.Body.Close()
+
+	if r.StatusCode != 200 {
+		return result, decorateError("get random postcode", errorFromHTTPCode(r.StatusCode))
+	}
+
+	err = decodeResultPayload(r, &result)
+	if err != nil {
+		err = decorateError("get random postcode", err)
+	}
+
+	return result, err
+}
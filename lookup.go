@@ -0,0 +1,250 @@
+package postcodesio
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+)
+
+// CodeSet holds the ONS codes for the administrative areas that a
+// postcode or outward code belongs to.
+type CodeSet struct {
+	Admin_district string `json:"admin_district"`
+	Admin_county   string `json:"admin_county"`
+	Admin_ward     string `json:"admin_ward"`
+	Parish         string `json:"parish"`
+	Ccg            string `json:"ccg"`
+	Nuts           string `json:"nuts"`
+}
+
+// PostcodeResult holds the full postcodes.io record for a single UK
+// postcode, as returned by LookupPostcode and ReverseGeocode.
+type PostcodeResult struct {
+	Postcode                   string  `json:"postcode"`
+	Quality                    int     `json:"quality"`
+	Eastings                   int     `json:"eastings"`
+	Northings                  int     `json:"northings"`
+	Nhs_ha                     string  `json:"nhs_ha"`
+	Longitude                  float64 `json:"longitude"`
+	Latitude                   float64 `json:"latitude"`
+	Parliamentary_constituency string  `json:"parliamentary_constituency"`
+	European_electoral_region  string  `json:"european_electoral_region"`
+	Primary_care_trust         string  `json:"primary_care_trust"`
+	Region                     string  `json:"region"`
+	Lsoa                       string  `json:"lsoa"`
+	Msoa                       string  `json:"msoa"`
+	Incode                     string  `json:"incode"`
+	Outcode                    string  `json:"outcode"`
+	Admin_district             string  `json:"admin_district"`
+	Parish                     string  `json:"parish"`
+	Admin_county               string  `json:"admin_county"`
+	Admin_ward                 string  `json:"admin_ward"`
+	Country                    string  `json:"country"`
+	Ccg                        string  `json:"ccg"`
+	Nuts                       string  `json:"nuts"`
+	Codes                      CodeSet `json:"codes"`
+}
+
+// OutcodeResult holds the full postcodes.io record for a UK outward
+// code (e.g. "SW1A"), as returned by LookupOutcode. An outward code
+// generally spans several administrative areas, so those fields are
+// slices rather than the single values found on PostcodeResult.
+type OutcodeResult struct {
+	Outcode        string   `json:"outcode"`
+	Longitude      float64  `json:"longitude"`
+	Latitude       float64  `json:"latitude"`
+	Northings      int      `json:"northings"`
+	Eastings       int      `json:"eastings"`
+	Admin_district []string `json:"admin_district"`
+	Parish         []string `json:"parish"`
+	Admin_county   []string `json:"admin_county"`
+	Admin_ward     []string `json:"admin_ward"`
+	Country        []string `json:"country"`
+}
+
+func lookupPostcodeURL(base, pc string) (string, error) {
+	uri, err := url.ParseRequestURI(base + "/postcodes/" + pc)
+	if err != nil {
+		return "", err
+	}
+	return uri.String(), nil
+}
+
+func lookupOutcodeURL(base, oc string) (string, error) {
+	uri, err := url.ParseRequestURI(base + "/outcodes/" + oc)
+	if err != nil {
+		return "", err
+	}
+	return uri.String(), nil
+}
+
+func decodeResultPayload(r *http.Response, result interface{}) error {
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return errors.New("could not read http response body: " + err.Error())
+	}
+
+	payload := struct {
+		Status int
+		Result interface{}
+		Error  string
+	}{Result: result}
+
+	jsonDecoder := json.NewDecoder(bytes.NewBuffer(body))
+	err = jsonDecoder.Decode(&payload)
+
+	if err != nil {
+		if err == io.EOF {
+			err = nil
+		}
+	}
+
+	// Did the decoded json contain an error message?
+	if err == nil && payload.Error != "" {
+		err = errors.New(payload.Error)
+	}
+
+	// Did the decoded json include a non 200 status? This would be
+	// surprising given that the response status should be checked before
+	// calling this function.
+	if err == nil && payload.Status != 200 {
+		err = errorFromHTTPCode(int(payload.Status))
+	}
+
+	return err
+}
+
+func decorateLookupError(kind string, err error) error {
+	return errors.New("postcodes.io: could not look up " + kind + ": " + err.Error())
+}
+
+// LookupPostcode returns the full postcodes.io record for the given UK
+// postcode, using DefaultClient. The record includes its administrative
+// district, parish, ward, region, country, LSOA/MSOA, CCG, NUTS and ONS
+// codes.
+func LookupPostcode(pc string) (PostcodeResult, error) {
+	return DefaultClient.LookupPostcode(pc)
+}
+
+// LookupPostcodeContext is like LookupPostcode but carries ctx onto the
+// underlying HTTP request, so callers can cancel or time it out.
+func LookupPostcodeContext(ctx context.Context, pc string) (PostcodeResult, error) {
+	return DefaultClient.LookupPostcodeContext(ctx, pc)
+}
+
+// LookupOutcode returns the full postcodes.io record for the given UK
+// outward code, e.g. "SW1A", using DefaultClient.
+func LookupOutcode(oc string) (OutcodeResult, error) {
+	return DefaultClient.LookupOutcode(oc)
+}
+
+// LookupOutcodeContext is like LookupOutcode but carries ctx onto the
+// underlying HTTP request, so callers can cancel or time it out.
+func LookupOutcodeContext(ctx context.Context, oc string) (OutcodeResult, error) {
+	return DefaultClient.LookupOutcodeContext(ctx, oc)
+}
+
+// LookupPostcode returns the full postcodes.io record for the given UK
+// postcode.
+func (c *Client) LookupPostcode(pc string) (PostcodeResult, error) {
+	return c.LookupPostcodeContext(context.Background(), pc)
+}
+
+// LookupPostcodeContext is like LookupPostcode but carries ctx onto the
+// underlying HTTP request, so callers can cancel or time it out. If the
+// Client was built with WithCache, a cached result for pc is returned
+// without making a request.
+func (c *Client) LookupPostcodeContext(ctx context.Context, pc string) (result PostcodeResult, err error) {
+
+	var cacheKey string
+	if c.cache != nil {
+		cacheKey = NormalizePostcode(pc)
+		if cached, ok := c.cache.Get(cacheKey); ok {
+			return cached, nil
+		}
+	}
+
+	u, err := lookupPostcodeURL(c.baseURL, pc)
+	if err != nil {
+		err = decorateLookupError("postcode", err)
+		return
+	}
+
+	req, err := c.newRequest(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		err = decorateLookupError("postcode", err)
+		return
+	}
+
+	r, err := c.do(req)
+	if err != nil {
+		err = decorateLookupError("postcode", err)
+		return
+	}
+	defer r.Body.Close()
+
+	if r.StatusCode != 200 {
+		err = decorateLookupError("postcode", errorFromHTTPCode(r.StatusCode))
+		return
+	}
+
+	err = decodeResultPayload(r, &result)
+	if err != nil {
+		err = decorateLookupError("postcode", err)
+		return
+	}
+
+	if c.cache != nil {
+		c.cache.Set(cacheKey, result, c.cacheTTL)
+	}
+
+	return
+}
+
+// LookupOutcode returns the full postcodes.io record for the given UK
+// outward code, e.g. "SW1A".
+func (c *Client) LookupOutcode(oc string) (OutcodeResult, error) {
+	return c.LookupOutcodeContext(context.Background(), oc)
+}
+
+// LookupOutcodeContext is like LookupOutcode but carries ctx onto the
+// underlying HTTP request, so callers can cancel or time it out.
+func (c *Client) LookupOutcodeContext(ctx context.Context, oc string) (result OutcodeResult, err error) {
+
+	u, err := lookupOutcodeURL(c.baseURL, oc)
+	if err != nil {
+		err = decorateLookupError("outcode", err)
+		return
+	}
+
+	req, err := c.newRequest(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		err = decorateLookupError("outcode", err)
+		return
+	}
+
+	r, err := c.do(req)
+	if err != nil {
+		err = decorateLookupError("outcode", err)
+		return
+	}
+	defer r.Body.Close()
+
+	if r.StatusCode != 200 {
+		err = decorateLookupError("outcode", errorFromHTTPCode(r.StatusCode))
+		return
+	}
+
+	err = decodeResultPayload(r, &result)
+	if err != nil {
+		err = decorateLookupError("outcode", err)
+	}
+
+	return
+}
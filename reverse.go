@@ -0,0 +1,133 @@
+package postcodesio
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// ReverseOption configures a call to ReverseGeocode.
+type ReverseOption func(*reverseGeocodeParams)
+
+type reverseGeocodeParams struct {
+	radius     int
+	limit      int
+	wideSearch bool
+}
+
+// WithRadius sets the search radius in metres, up to 2000. postcodes.io
+// defaults to 100m when no radius is given.
+func WithRadius(metres int) ReverseOption {
+	return func(p *reverseGeocodeParams) {
+		p.radius = metres
+	}
+}
+
+// WithLimit sets the maximum number of postcodes to return, up to 100.
+// postcodes.io defaults to 10 when no limit is given.
+func WithLimit(n int) ReverseOption {
+	return func(p *reverseGeocodeParams) {
+		p.limit = n
+	}
+}
+
+// WithWideSearch enables postcodes.io's widesearch fallback, which looks
+// further afield (up to 20km) when nothing is found within the radius, at
+// the cost of slower responses.
+func WithWideSearch(wide bool) ReverseOption {
+	return func(p *reverseGeocodeParams) {
+		p.wideSearch = wide
+	}
+}
+
+func reverseGeocodeURL(base string, pt GeoPoint, opts ...ReverseOption) (string, error) {
+
+	var p reverseGeocodeParams
+	for _, opt := range opts {
+		opt(&p)
+	}
+
+	uri, err := url.ParseRequestURI(base + "/postcodes")
+	if err != nil {
+		return "", err
+	}
+
+	q := url.Values{}
+	q.Set("lon", strconv.FormatFloat(pt.Longitude, 'f', -1, 64))
+	q.Set("lat", strconv.FormatFloat(pt.Latitude, 'f', -1, 64))
+	if p.radius != 0 {
+		q.Set("radius", strconv.Itoa(p.radius))
+	}
+	if p.limit != 0 {
+		q.Set("limit", strconv.Itoa(p.limit))
+	}
+	if p.wideSearch {
+		q.Set("widesearch", "true")
+	}
+	uri.RawQuery = q.Encode()
+
+	return uri.String(), nil
+}
+
+func decorateReverseGeocodingError(err error) error {
+	return errors.New("postcodes.io: could not reverse geocode point: " + err.Error())
+}
+
+// ReverseGeocode returns the postcodes nearest to the given point,
+// nearest first, using DefaultClient. By default postcodes.io searches
+// within 100m and returns up to 10 results; use WithRadius and WithLimit
+// to widen or narrow that, and WithWideSearch to fall back to a much
+// larger radius when nothing nearby is found.
+func ReverseGeocode(pt GeoPoint, opts ...ReverseOption) ([]PostcodeResult, error) {
+	return DefaultClient.ReverseGeocode(pt, opts...)
+}
+
+// ReverseGeocodeContext is like ReverseGeocode but carries ctx onto the
+// underlying HTTP request, so callers can cancel or time it out.
+func ReverseGeocodeContext(ctx context.Context, pt GeoPoint, opts ...ReverseOption) ([]PostcodeResult, error) {
+	return DefaultClient.ReverseGeocodeContext(ctx, pt, opts...)
+}
+
+// ReverseGeocode returns the postcodes nearest to the given point,
+// nearest first.
+func (c *Client) ReverseGeocode(pt GeoPoint, opts ...ReverseOption) ([]PostcodeResult, error) {
+	return c.ReverseGeocodeContext(context.Background(), pt, opts...)
+}
+
+// ReverseGeocodeContext is like ReverseGeocode but carries ctx onto the
+// underlying HTTP request, so callers can cancel or time it out.
+func (c *Client) ReverseGeocodeContext(ctx context.Context, pt GeoPoint, opts ...ReverseOption) (results []PostcodeResult, err error) {
+
+	u, err := reverseGeocodeURL(c.baseURL, pt, opts...)
+	if err != nil {
+		err = decorateReverseGeocodingError(err)
+		return
+	}
+
+	req, err := c.newRequest(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		err = decorateReverseGeocodingError(err)
+		return
+	}
+
+	r, err := c.do(req)
+	if err != nil {
+		err = decorateReverseGeocodingError(err)
+		return
+	}
+	defer r.Body.Close()
+
+	if r.StatusCode != 200 {
+		err = decorateReverseGeocodingError(errorFromHTTPCode(r.StatusCode))
+		return
+	}
+
+	err = decodeResultPayload(r, &results)
+	if err != nil {
+		err = decorateReverseGeocodingError(err)
+	}
+
+	return
+}